@@ -6,18 +6,28 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
-	"time"
+	"url_shortener/internal/app/lifecycle"
+	"url_shortener/internal/clients/oidc"
 	ssogrpc "url_shortener/internal/clients/sso/grpc"
 	"url_shortener/internal/config"
+	"url_shortener/internal/lib/authz"
 	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/session"
+	sessionmem "url_shortener/internal/session/memory"
+	sessionpg "url_shortener/internal/session/postgres"
 	"url_shortener/internal/storage/postgres"
 	admDel "url_shortener/internal/transport/handlers/admins/delete"
 	admSet "url_shortener/internal/transport/handlers/admins/set"
+	authCallback "url_shortener/internal/transport/handlers/auth/callback"
+	authLogin "url_shortener/internal/transport/handlers/auth/login"
+	authLogout "url_shortener/internal/transport/handlers/auth/logout"
 	urlDel "url_shortener/internal/transport/handlers/url/delete"
 	urlRed "url_shortener/internal/transport/handlers/url/redirect"
 	urlSave "url_shortener/internal/transport/handlers/url/save"
 	"url_shortener/internal/transport/middleware/auth"
 	mwLogger "url_shortener/internal/transport/middleware/logger"
+	"url_shortener/internal/transport/middleware/ratelimit"
+	mwSession "url_shortener/internal/transport/middleware/session"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -27,54 +37,132 @@ func RunServer(ctx context.Context, log *slog.Logger, cfg *config.Config) error
 	const op = "internal.app.RunServer"
 	log.With(slog.String("op", op))
 
-	// init ssoServer
-	log.Info("init ssoServer", slog.String("env", cfg.Env))
-	log.Debug("creddentials sso", slog.String("address", cfg.Clients.SSO.Address))
-	ssoClient, err := ssogrpc.New(
-		context.Background(),
-		log, cfg.Clients.SSO.Address,
-		cfg.Clients.SSO.Timeout,
-		cfg.Clients.SSO.RetriesCount,
-	)
-	if err != nil {
-		log.Error("failed to init ssoClient", sl.Err(err))
-		return fmt.Errorf("%s: %w", op, err)
-	}
-	log.Info("ssoClient was init")
+	shutdown := lifecycle.NewGroup()
+	shutdown.Register(lifecycle.Component{
+		Name: "logger",
+		Stop: func(ctx context.Context) error {
+			log.Info("flushing logs")
+			return nil
+		},
+	})
+
+	var ssoClient *ssogrpc.Client
+	shutdown.Register(lifecycle.Component{
+		Name: "sso client",
+		Start: func(ctx context.Context) error {
+			log.Info("init ssoServer", slog.String("env", cfg.Env))
+			log.Debug("creddentials sso", slog.String("address", cfg.Clients.SSO.Address))
+			c, err := ssogrpc.New(ctx, log, cfg.Clients.SSO.Address, cfg.Clients.SSO.Timeout, cfg.Clients.SSO.RetriesCount)
+			if err != nil {
+				return err
+			}
+			ssoClient = c
+			log.Info("ssoClient was init")
+			return nil
+		},
+		Stop: func(ctx context.Context) error { return ssoClient.Close() },
+	})
 
-	// init postgresql storage
-	storage, err := postgres.NewStorage(cfg)
-	if err != nil {
-		log.Error("failed to init storage", sl.Err(err))
+	var storageImpl *postgres.Storage
+	shutdown.Register(lifecycle.Component{
+		Name: "storage",
+		Start: func(ctx context.Context) error {
+			s, err := postgres.NewStorage(cfg)
+			if err != nil {
+				return err
+			}
+			storageImpl = s
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			storageImpl.CloseStorage()
+			return nil
+		},
+	})
+
+	var sessionStore session.Store
+	shutdown.Register(lifecycle.Component{
+		Name: "session store",
+		Start: func(ctx context.Context) error {
+			if cfg.Session.Backend == "postgres" {
+				s, err := sessionpg.New(cfg)
+				if err != nil {
+					return err
+				}
+				sessionStore = s
+				return nil
+			}
+			sessionStore = sessionmem.New()
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			closer, ok := sessionStore.(interface{ Close() error })
+			if !ok {
+				return nil
+			}
+			return closer.Close()
+		},
+	})
+
+	if err := shutdown.Start(ctx, cfg.Lifecycle.StartTimeout); err != nil {
+		log.Error("failed to start dependencies", sl.Err(err))
 		return fmt.Errorf("%s: %w", op, err)
 	}
-	defer storage.CloseStorage()
+	storage := storageImpl
+
+	oidcClient := oidc.New(oidc.Config{
+		AuthURL:      cfg.OIDC.AuthURL,
+		TokenURL:     cfg.OIDC.TokenURL,
+		ClientID:     cfg.OIDC.ClientID,
+		ClientSecret: cfg.OIDC.ClientSecret,
+		RedirectURL:  cfg.OIDC.RedirectURL,
+		Secret:       cfg.AppSecret,
+	})
 
 	// init router
 	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
 	router.Use(middleware.Logger)
 	router.Use(mwLogger.New(log))
-	router.Use(middleware.RequestID)
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.URLFormat)
 
+	redirectLimiter := ratelimit.NewTokenBucket(cfg.RateLimit.Redirect)
+	urlLimiter := ratelimit.NewTokenBucket(cfg.RateLimit.URL)
+
+	// scope registry, for introspection of which route needs which scope
+	scopes := authz.NewRegistry()
+	scopes.Register("POST /url", urlSave.RequiredScopes...)
+	scopes.Register("DELETE /url/{alias}", urlDel.RequiredScopes...)
+	scopes.Register("GET /{alias}", urlRed.RequiredScopes...)
+	scopes.Register("POST /user", admSet.RequiredScopes...)
+	scopes.Register("DELETE /user", admDel.RequiredScopes...)
+
 	// url router
 	router.Route("/url", func(r chi.Router) {
-		r.Use(auth.New(log, cfg.AppSecret, ssoClient))
-		r.Post("/", urlSave.New(log, storage))
-		r.Delete("/{alias}", urlDel.New(log, storage))
+		r.Use(auth.New(cfg.AppSecret, ssoClient))
+		r.Use(ratelimit.New(urlLimiter, ratelimit.BySubjectOrIP))
+		r.With(scopes.Middleware("POST /url")).Post("/", urlSave.New(storage))
+		r.With(scopes.Middleware("DELETE /url/{alias}")).Delete("/{alias}", urlDel.New(storage))
 	})
-	router.Get("/{alias}", urlRed.New(log, storage))
+	router.With(
+		ratelimit.New(redirectLimiter, ratelimit.ByIP),
+		scopes.Middleware("GET /{alias}"),
+	).Get("/{alias}", urlRed.New(storage))
+
+	// OIDC + PKCE login flow for the /user admin area
+	router.Get("/user/auth/login", authLogin.New(cfg.AppSecret, oidcClient))
+	router.Get("/user/auth/callback", authCallback.New(cfg.AppSecret, oidcClient, sessionStore))
+	router.Post("/user/auth/logout", authLogout.New(sessionStore))
 
 	/// user router
 	router.Route("/user", func(r chi.Router) {
-		r.Use(middleware.BasicAuth("url_shortener", map[string]string{cfg.HTTPServer.User: cfg.HTTPServer.Password}))
-		r.Post("/", admSet.New(log, ssoClient))
-		r.Delete("/", admDel.New(log, ssoClient))
+		r.Use(mwSession.New(sessionStore))
+		r.With(scopes.Middleware("POST /user")).Post("/", admSet.New(ssoClient, cfg.AppSecret))
+		r.With(scopes.Middleware("DELETE /user")).Delete("/", admDel.New(ssoClient, cfg.AppSecret))
 	})
 
 	// start server
-	log.Info("starting server")
 	srv := &http.Server{
 		Addr:         cfg.Address,
 		Handler:      router,
@@ -82,22 +170,31 @@ func RunServer(ctx context.Context, log *slog.Logger, cfg *config.Config) error
 		WriteTimeout: cfg.HTTPServer.Timeout,
 		IdleTimeout:  cfg.HTTPServer.IdleTimeout,
 	}
-	go func() {
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Error("failed to start server")
-			os.Exit(1)
-		}
-	}()
-	log.Info("server start", slog.String("addresses", cfg.Address))
+	shutdown.Register(lifecycle.Component{
+		Name: "http server",
+		Start: func(ctx context.Context) error {
+			log.Info("starting server")
+			go func() {
+				if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+					log.Error("failed to start server")
+					os.Exit(1)
+				}
+			}()
+			log.Info("server start", slog.String("addresses", cfg.Address))
+			return nil
+		},
+		Stop: func(ctx context.Context) error { return srv.Shutdown(ctx) },
+	})
+	if err := shutdown.Start(ctx, cfg.Lifecycle.StartTimeout); err != nil {
+		log.Error("failed to start http server", sl.Err(err))
+		return fmt.Errorf("%s: %w", op, err)
+	}
 
 	// wait for gracefully shutdown
 	<-ctx.Done()
 	log.Info("shutting down server gracefully")
-	shutDownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-	if err := srv.Shutdown(shutDownCtx); err != nil {
+	if err := shutdown.Shutdown(context.Background(), cfg.Lifecycle.ShutdownTimeout); err != nil {
 		return fmt.Errorf("shutdown: %w", err)
 	}
-	<-shutDownCtx.Done()
 	return nil
 }
@@ -0,0 +1,120 @@
+// Package lifecycle coordinates startup and graceful shutdown of
+// RunServer's dependencies: the HTTP server, storage pool, and SSO client
+// all need to come up in a defined order, and go back down in the reverse
+// of that order within a bounded time - and a failure partway through
+// startup must still unwind whatever already came up.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Component is a dependency with an explicit, bounded-time start and stop.
+// Start may be nil for a component with nothing to initialize (it is
+// skipped); Stop runs for every component that started successfully,
+// including when a later component's Start fails.
+type Component struct {
+	Name  string
+	Start func(ctx context.Context) error
+	Stop  func(ctx context.Context) error
+}
+
+// Group starts its registered components in registration order and stops
+// them in the reverse order, so whatever was started last - and is
+// therefore most likely to depend on everything before it - is the first
+// to go down.
+type Group struct {
+	components []Component
+	started    int // index of the first not-yet-started component
+}
+
+// NewGroup returns an empty Group.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Register adds c to the group. Call order matters: c.Start runs after the
+// Start of everything registered earlier, and c.Stop runs before the Stop
+// of anything registered earlier.
+func (g *Group) Register(c Component) {
+	g.components = append(g.components, c)
+}
+
+// Start runs the Start hook of every component registered since the last
+// call to Start, in registration order, giving each up to timeout to
+// return. Calling Start again after registering more components only
+// starts the new ones - so a caller can register and start dependencies in
+// stages (e.g. backing services before the HTTP server that depends on
+// them being up).
+//
+// If a Start hook fails, Start stops every component that already started
+// in this or a previous call (in reverse order, via their Stop hooks)
+// before returning the original error.
+func (g *Group) Start(ctx context.Context, timeout time.Duration) error {
+	for ; g.started < len(g.components); g.started++ {
+		c := g.components[g.started]
+		if c.Start == nil {
+			continue
+		}
+
+		cctx, cancel := context.WithTimeout(ctx, timeout)
+		err := c.Start(cctx)
+		cancel()
+		if err == nil {
+			continue
+		}
+
+		startErr := fmt.Errorf("%s: %w", c.Name, err)
+		stopErr := g.stopFrom(ctx, timeout, g.started-1)
+		g.started = 0
+		if stopErr != nil {
+			return fmt.Errorf("%w (cleanup after failed start: %s)", startErr, stopErr)
+		}
+		return startErr
+	}
+	return nil
+}
+
+// Shutdown stops every registered component in reverse-registration order,
+// giving each one up to timeout to return. A component that errors or times
+// out does not stop the rest from being shut down; every error encountered
+// is aggregated into the returned error.
+func (g *Group) Shutdown(ctx context.Context, timeout time.Duration) error {
+	return g.stopFrom(ctx, timeout, len(g.components)-1)
+}
+
+// stopFrom stops components[0..from] in reverse order.
+func (g *Group) stopFrom(ctx context.Context, timeout time.Duration, from int) error {
+	var errs []error
+	for i := from; i >= 0; i-- {
+		c := g.components[i]
+		if c.Stop == nil {
+			continue
+		}
+
+		cctx, cancel := context.WithTimeout(ctx, timeout)
+		err := c.Stop(cctx)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Name, err))
+		}
+	}
+	return joinErrors(errs)
+}
+
+func joinErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Errorf("%d components failed to stop: %s", len(errs), strings.Join(msgs, "; "))
+}
@@ -0,0 +1,77 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGroup_StartFailurePartwayStopsStartedComponents(t *testing.T) {
+	var stopped []string
+
+	g := NewGroup()
+	g.Register(Component{
+		Name:  "a",
+		Start: func(ctx context.Context) error { return nil },
+		Stop:  func(ctx context.Context) error { stopped = append(stopped, "a"); return nil },
+	})
+	g.Register(Component{
+		Name:  "b",
+		Start: func(ctx context.Context) error { return nil },
+		Stop:  func(ctx context.Context) error { stopped = append(stopped, "b"); return nil },
+	})
+	failErr := errors.New("boom")
+	g.Register(Component{
+		Name:  "c",
+		Start: func(ctx context.Context) error { return failErr },
+		Stop:  func(ctx context.Context) error { stopped = append(stopped, "c"); return nil },
+	})
+	g.Register(Component{
+		Name:  "d",
+		Start: func(ctx context.Context) error { t.Fatal("d should never start"); return nil },
+		Stop:  func(ctx context.Context) error { stopped = append(stopped, "d"); return nil },
+	})
+
+	err := g.Start(context.Background(), time.Second)
+	if !errors.Is(err, failErr) {
+		t.Fatalf("Start() error = %v, want wrapping %v", err, failErr)
+	}
+
+	// c never finished starting, so it is never stopped. d was never
+	// reached at all. Only a and b, which started successfully, must be
+	// unwound, in the reverse of their start order.
+	want := []string{"b", "a"}
+	if len(stopped) != len(want) {
+		t.Fatalf("stopped = %v, want %v", stopped, want)
+	}
+	for i := range want {
+		if stopped[i] != want[i] {
+			t.Fatalf("stopped = %v, want %v", stopped, want)
+		}
+	}
+}
+
+func TestGroup_ShutdownStopsInReverseOrderAndAggregatesErrors(t *testing.T) {
+	var stopped []string
+
+	g := NewGroup()
+	g.Register(Component{
+		Name: "a",
+		Stop: func(ctx context.Context) error { stopped = append(stopped, "a"); return errors.New("a failed") },
+	})
+	g.Register(Component{
+		Name: "b",
+		Stop: func(ctx context.Context) error { stopped = append(stopped, "b"); return nil },
+	})
+
+	err := g.Shutdown(context.Background(), time.Second)
+	if err == nil {
+		t.Fatal("Shutdown() error = nil, want non-nil (component a failed)")
+	}
+
+	want := []string{"b", "a"}
+	if len(stopped) != len(want) || stopped[0] != want[0] || stopped[1] != want[1] {
+		t.Fatalf("stopped = %v, want %v", stopped, want)
+	}
+}
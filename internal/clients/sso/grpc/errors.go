@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sentinel errors callers should check with errors.Is instead of matching
+// on err.Error(), which breaks the moment the gRPC status message changes.
+var (
+	ErrInvalidCredentials = errors.New("sso: invalid credentials")
+	ErrUnauthenticated    = errors.New("sso: unauthenticated")
+	ErrPermissionDenied   = errors.New("sso: permission denied")
+	ErrUserNotFound       = errors.New("sso: user not found")
+	ErrUnavailable        = errors.New("sso: service unavailable")
+	ErrDeadlineExceeded   = errors.New("sso: deadline exceeded")
+)
+
+// AuthError wraps a failed SSO RPC with the gRPC status code it came from,
+// so callers that need the raw code (metrics, retry policy) can still get
+// it while using errors.Is against the sentinels above for control flow.
+type AuthError struct {
+	Code codes.Code
+	Err  error
+}
+
+func (e *AuthError) Error() string {
+	return fmt.Sprintf("sso: %s: %s", e.Code, e.Err)
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.Err
+}
+
+// mapError translates a gRPC error into an *AuthError wrapping one of the
+// sentinels above. Codes this package doesn't have a typed sentinel for
+// are returned unwrapped.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	var sentinel error
+	switch st.Code() {
+	case codes.InvalidArgument:
+		sentinel = ErrInvalidCredentials
+	case codes.Unauthenticated:
+		sentinel = ErrUnauthenticated
+	case codes.PermissionDenied:
+		sentinel = ErrPermissionDenied
+	case codes.NotFound:
+		sentinel = ErrUserNotFound
+	case codes.Unavailable:
+		sentinel = ErrUnavailable
+	case codes.DeadlineExceeded:
+		sentinel = ErrDeadlineExceeded
+	default:
+		return err
+	}
+
+	return &AuthError{Code: st.Code(), Err: sentinel}
+}
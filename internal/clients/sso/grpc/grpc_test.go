@@ -0,0 +1,137 @@
+package grpc_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	ssogrpc "url_shortener/internal/clients/sso/grpc"
+
+	ssov1 "github.com/neepooha/protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeAuthServer lets each test dictate exactly the status code the SSO
+// service responds with, so grpc.mapError's translation can be exercised
+// without a real SSO deployment.
+type fakeAuthServer struct {
+	ssov1.UnimplementedAuthServer
+	err error
+}
+
+func (s *fakeAuthServer) SetAdmin(context.Context, *ssov1.SetAdminRequest) (*ssov1.SetAdminResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ssov1.SetAdminResponse{Success: true}, nil
+}
+
+func (s *fakeAuthServer) DeleteAdmin(context.Context, *ssov1.DeleteAdminRequest) (*ssov1.DeleteAdminResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ssov1.DeleteAdminResponse{Success: true}, nil
+}
+
+func (s *fakeAuthServer) IsTokenRevoked(context.Context, *ssov1.IsTokenRevokedRequest) (*ssov1.IsTokenRevokedResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ssov1.IsTokenRevokedResponse{Revoked: true}, nil
+}
+
+// newTestClient starts an in-process gRPC server backed by fake and returns
+// a *ssogrpc.Client dialed to it over bufconn. Server and connection are
+// torn down through t.Cleanup.
+func newTestClient(t *testing.T, fake *fakeAuthServer) *ssogrpc.Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	ssov1.RegisterAuthServer(srv, fake)
+	go func() {
+		if err := srv.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			t.Logf("bufconn server stopped: %v", err)
+		}
+	}()
+	t.Cleanup(srv.Stop)
+
+	dialer := func(context.Context, string) (net.Conn, error) { return lis.Dial() }
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(dialer),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client, err := ssogrpc.NewWithConn(conn, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("ssogrpc.NewWithConn: %v", err)
+	}
+	return client
+}
+
+func TestClient_SetAdmin(t *testing.T) {
+	tests := []struct {
+		name    string
+		srvErr  error
+		wantErr error
+	}{
+		{name: "success", srvErr: nil},
+		{name: "invalid argument maps to ErrInvalidCredentials", srvErr: status.Error(codes.InvalidArgument, "invalid credentials"), wantErr: ssogrpc.ErrInvalidCredentials},
+		{name: "permission denied maps to ErrPermissionDenied", srvErr: status.Error(codes.PermissionDenied, "forbidden"), wantErr: ssogrpc.ErrPermissionDenied},
+		{name: "not found maps to ErrUserNotFound", srvErr: status.Error(codes.NotFound, "no such user"), wantErr: ssogrpc.ErrUserNotFound},
+		{name: "unavailable maps to ErrUnavailable", srvErr: status.Error(codes.Unavailable, "down"), wantErr: ssogrpc.ErrUnavailable},
+		{name: "deadline exceeded maps to ErrDeadlineExceeded", srvErr: status.Error(codes.DeadlineExceeded, "too slow"), wantErr: ssogrpc.ErrDeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, &fakeAuthServer{err: tt.srvErr})
+
+			ok, err := client.SetAdmin(context.Background(), "a@b.com", 1)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("SetAdmin() error = %v, want nil", err)
+				}
+				if !ok {
+					t.Fatal("SetAdmin() success = false, want true")
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetAdmin() error = %v, want wrapped %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteAdmin_MapsNotFound(t *testing.T) {
+	client := newTestClient(t, &fakeAuthServer{err: status.Error(codes.NotFound, "no such user")})
+
+	_, err := client.DeleteAdmin(context.Background(), "a@b.com", 1)
+	if !errors.Is(err, ssogrpc.ErrUserNotFound) {
+		t.Fatalf("DeleteAdmin() error = %v, want wrapped %v", err, ssogrpc.ErrUserNotFound)
+	}
+}
+
+func TestClient_IsRevoked(t *testing.T) {
+	client := newTestClient(t, &fakeAuthServer{})
+
+	revoked, err := client.IsRevoked(context.Background(), "some-jti")
+	if err != nil {
+		t.Fatalf("IsRevoked() error = %v", err)
+	}
+	if !revoked {
+		t.Fatal("IsRevoked() = false, want true")
+	}
+}
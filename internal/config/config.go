@@ -0,0 +1,70 @@
+// Package config declares url_shortener's configuration types.
+package config
+
+import (
+	"time"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/transport/middleware/ratelimit"
+)
+
+type Config struct {
+	Env         string `yaml:"env" env-default:"local"`
+	StoragePath string `yaml:"storage_path" env-required:"true"`
+	AppSecret   string `yaml:"app_secret" env-required:"true"`
+	Address     string `yaml:"address" env-default:"localhost:8080"`
+
+	Log        applogger.Config `yaml:"log"`
+	HTTPServer HTTPServer       `yaml:"http_server"`
+	Clients    ClientsConfig    `yaml:"clients"`
+	Session    SessionConfig    `yaml:"session"`
+	OIDC       OIDCConfig       `yaml:"oidc"`
+	RateLimit  RateLimitConfig  `yaml:"rate_limit"`
+	Lifecycle  LifecycleConfig  `yaml:"lifecycle"`
+}
+
+type HTTPServer struct {
+	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
+	IdleTimeout time.Duration `yaml:"idle_timeout" env-default:"60s"`
+}
+
+type ClientsConfig struct {
+	SSO SSOConfig `yaml:"sso"`
+}
+
+type SSOConfig struct {
+	Address      string        `yaml:"address"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"2s"`
+	RetriesCount int           `yaml:"retries_count" env-default:"3"`
+}
+
+// SessionConfig selects the session.Store implementation backing the
+// OIDC-authenticated /user routes: "memory" (default, dev-only) or
+// "postgres".
+type SessionConfig struct {
+	Backend string `yaml:"backend" env-default:"memory"`
+}
+
+// OIDCConfig points at the SSO service's authorization-code + PKCE
+// endpoints used by handlers/auth/{login,callback}.
+type OIDCConfig struct {
+	AuthURL      string `yaml:"auth_url"`
+	TokenURL     string `yaml:"token_url"`
+	ClientID     string `yaml:"client_id"`
+	ClientSecret string `yaml:"client_secret"`
+	RedirectURL  string `yaml:"redirect_url"`
+}
+
+// RateLimitConfig holds the token-bucket parameters for each rate-limited
+// route, keyed the same way middleware/ratelimit keys its buckets: redirect
+// by client IP, url by authenticated subject (falling back to IP).
+type RateLimitConfig struct {
+	Redirect ratelimit.Config `yaml:"redirect"`
+	URL      ratelimit.Config `yaml:"url"`
+}
+
+// LifecycleConfig bounds how long the lifecycle.Group RunServer builds may
+// spend bringing a single component up or down.
+type LifecycleConfig struct {
+	StartTimeout    time.Duration `yaml:"start_timeout" env-default:"5s"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"5s"`
+}
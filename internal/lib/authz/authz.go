@@ -0,0 +1,113 @@
+// Package authz declares the scopes the API recognises and the chi
+// middleware used to enforce them on top of the authenticated claims
+// stashed in the request context by middleware/auth.
+package authz
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/transport/middleware/auth"
+
+	"github.com/go-chi/render"
+)
+
+// Scope is a single permission carried by a JWT, e.g. "url:write".
+type Scope string
+
+const (
+	ScopeURLWrite    Scope = "url:write"
+	ScopeURLDelete   Scope = "url:delete"
+	ScopeAdminGrant  Scope = "admin:grant"
+	ScopeAdminRevoke Scope = "admin:revoke"
+)
+
+// Registry records which scopes a route requires. Handlers declare their
+// own RequiredScopes, RunServer registers them here so the mapping can be
+// inspected (tests, ops tooling) in one place.
+type Registry struct {
+	mu     sync.RWMutex
+	routes map[string][]Scope
+}
+
+func NewRegistry() *Registry {
+	return &Registry{routes: make(map[string][]Scope)}
+}
+
+func (r *Registry) Register(route string, scopes ...Scope) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.routes[route] = scopes
+}
+
+func (r *Registry) Required(route string) []Scope {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.routes[route]
+}
+
+// Middleware returns the chi middleware enforcing whatever scopes are
+// registered for route, so the registry is the single source of truth
+// RunServer's routing and any future introspection (ops tooling, tests)
+// both read from. The scopes are read through Required once, when
+// Middleware is called; a later Register for the same route does not
+// change an already-built middleware.
+func (r *Registry) Middleware(route string) func(http.Handler) http.Handler {
+	return RequireScopes(r.Required(route)...)
+}
+
+// hasScope reports whether tokenScopes satisfies required, treating a
+// "prefix:*" entry as an admin override for every scope under that prefix.
+func hasScope(tokenScopes []string, required Scope) bool {
+	for _, s := range tokenScopes {
+		if s == string(required) {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(s, "*"); ok && strings.HasPrefix(string(required), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAll reports whether tokenScopes satisfies every scope in required.
+func HasAll(tokenScopes []string, required []Scope) bool {
+	for _, s := range required {
+		if !hasScope(tokenScopes, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// RequireScopes returns a chi middleware that 401s when the request has no
+// authenticated claims and 403s when the claims are missing one of the
+// required scopes. A handler that requires no scopes (e.g. a public route)
+// should pass no arguments, in which case the middleware is a no-op.
+func RequireScopes(required ...Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if len(required) == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			claims, ok := auth.FromContext(r.Context())
+			if !ok {
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+				return
+			}
+
+			if !HasAll(claims.Scopes, required) {
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, resp.Error("missing required scope"))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package authz_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"url_shortener/internal/lib/authz"
+	authmw "url_shortener/internal/transport/middleware/auth"
+)
+
+func newRequestWithScopes(t *testing.T, scopes []string, withClaims bool) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodPost, "/url", nil)
+	if !withClaims {
+		return r
+	}
+
+	claims := authmw.Claims{Scopes: scopes}
+	return r.WithContext(authmw.NewContext(r.Context(), claims))
+}
+
+func TestRequireScopes(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	tests := []struct {
+		name       string
+		required   []authz.Scope
+		tokenScope []string
+		withClaims bool
+		wantStatus int
+	}{
+		{
+			name:       "token carries the required scope",
+			required:   []authz.Scope{authz.ScopeURLWrite},
+			tokenScope: []string{"url:write"},
+			withClaims: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "token missing the required scope",
+			required:   []authz.Scope{authz.ScopeURLWrite},
+			tokenScope: []string{"url:delete"},
+			withClaims: true,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "no authenticated claims at all",
+			required:   []authz.Scope{authz.ScopeURLWrite},
+			withClaims: false,
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "admin wildcard scope overrides a specific requirement",
+			required:   []authz.Scope{authz.ScopeAdminGrant},
+			tokenScope: []string{"admin:*"},
+			withClaims: true,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "no required scopes is a no-op, even unauthenticated",
+			required:   nil,
+			withClaims: false,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := authz.RequireScopes(tt.required...)(http.HandlerFunc(ok))
+
+			w := httptest.NewRecorder()
+			r := newRequestWithScopes(t, tt.tokenScope, tt.withClaims)
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRegistry_Middleware(t *testing.T) {
+	reg := authz.NewRegistry()
+	reg.Register("POST /url", authz.ScopeURLWrite)
+
+	ok := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+	handler := reg.Middleware("POST /url")(http.HandlerFunc(ok))
+
+	w := httptest.NewRecorder()
+	r := newRequestWithScopes(t, []string{"url:delete"}, true)
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d (registry scopes must actually be enforced)", w.Code, http.StatusForbidden)
+	}
+}
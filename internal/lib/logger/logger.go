@@ -0,0 +1,64 @@
+// Package logger builds the root *slog.Logger from config and carries a
+// per-request child logger on the request context, so handlers stop
+// re-deriving request_id (and friends) themselves.
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type ctxKey int
+
+const loggerCtxKey ctxKey = iota
+
+// Config controls the root logger's level and output format.
+type Config struct {
+	Level  string `yaml:"level" env:"LOG_LEVEL" env-default:"info"`
+	Format string `yaml:"format" env:"LOG_FORMAT" env-default:"text"`
+}
+
+// New builds the root logger RunServer passes to middleware/logger, which
+// in turn derives every request-scoped logger from it.
+func New(cfg Config) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(cfg.Level)}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "json") {
+		handler = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		handler = slog.NewTextHandler(os.Stdout, opts)
+	}
+
+	return slog.New(handler)
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewContext stores log on ctx for FromContext to retrieve downstream.
+func NewContext(ctx context.Context, log *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerCtxKey, log)
+}
+
+// FromContext returns the logger middleware/logger stashed on ctx. Code
+// running outside of the HTTP middleware chain (tests, background jobs)
+// gets a default logger instead of having to nil-check.
+func FromContext(ctx context.Context) *slog.Logger {
+	if log, ok := ctx.Value(loggerCtxKey).(*slog.Logger); ok {
+		return log
+	}
+	return slog.New(slog.NewTextHandler(os.Stderr, nil))
+}
@@ -0,0 +1,47 @@
+// Package memory is an in-memory session.Store, used in dev and tests.
+package memory
+
+import (
+	"context"
+	"sync"
+	"url_shortener/internal/session"
+)
+
+type Store struct {
+	mu   sync.RWMutex
+	byID map[string]session.Session
+}
+
+func New() *Store {
+	return &Store{byID: make(map[string]session.Session)}
+}
+
+func (s *Store) Create(_ context.Context, sess session.Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.byID[sess.ID] = sess
+	return nil
+}
+
+func (s *Store) Get(_ context.Context, id string) (session.Session, error) {
+	s.mu.RLock()
+	sess, ok := s.byID[id]
+	s.mu.RUnlock()
+	if !ok {
+		return session.Session{}, session.ErrNotFound
+	}
+	if sess.Expired() {
+		s.mu.Lock()
+		delete(s.byID, id)
+		s.mu.Unlock()
+		return session.Session{}, session.ErrExpired
+	}
+	return sess, nil
+}
+
+func (s *Store) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.byID, id)
+	return nil
+}
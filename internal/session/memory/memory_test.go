@@ -0,0 +1,52 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	"url_shortener/internal/session"
+	"url_shortener/internal/session/memory"
+)
+
+func TestStore_Get_ExpiredSessionIsReportedThenEvicted(t *testing.T) {
+	store := memory.New()
+	ctx := context.Background()
+
+	sess := session.Session{ID: "expired-id", ExpiresAt: time.Now().Add(-time.Minute)}
+	if err := store.Create(ctx, sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	_, err := store.Get(ctx, sess.ID)
+	if !errors.Is(err, session.ErrExpired) {
+		t.Fatalf("Get() error = %v, want %v", err, session.ErrExpired)
+	}
+
+	if _, err := store.Get(ctx, sess.ID); !errors.Is(err, session.ErrNotFound) {
+		t.Fatalf("Get() after expiry eviction error = %v, want %v", err, session.ErrNotFound)
+	}
+}
+
+func TestStore_Get_UnknownIDIsNotFound(t *testing.T) {
+	store := memory.New()
+	if _, err := store.Get(context.Background(), "nope"); !errors.Is(err, session.ErrNotFound) {
+		t.Fatalf("Get() error = %v, want %v", err, session.ErrNotFound)
+	}
+}
+
+func TestStore_Delete(t *testing.T) {
+	store := memory.New()
+	ctx := context.Background()
+
+	sess := session.Session{ID: "id", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.Create(ctx, sess); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := store.Delete(ctx, sess.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := store.Get(ctx, sess.ID); !errors.Is(err, session.ErrNotFound) {
+		t.Fatalf("Get() after Delete() error = %v, want %v", err, session.ErrNotFound)
+	}
+}
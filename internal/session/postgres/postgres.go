@@ -0,0 +1,87 @@
+// Package postgres is the Postgres-backed session.Store, used in
+// production so sessions survive a restart and are shared across
+// replicas.
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"url_shortener/internal/config"
+	"url_shortener/internal/session"
+
+	_ "github.com/lib/pq"
+)
+
+type Store struct {
+	db *sql.DB
+}
+
+func New(cfg *config.Config) (*Store, error) {
+	const op = "session.postgres.New"
+
+	db, err := sql.Open("postgres", cfg.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Create(ctx context.Context, sess session.Session) error {
+	const op = "session.postgres.Create"
+
+	const query = `
+		INSERT INTO sessions (id, user_id, email, role, expires_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET user_id = $2, email = $3, role = $4, expires_at = $5`
+
+	if _, err := s.db.ExecContext(ctx, query, sess.ID, sess.UserID, sess.Email, sess.Role, sess.ExpiresAt); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+func (s *Store) Get(ctx context.Context, id string) (session.Session, error) {
+	const op = "session.postgres.Get"
+
+	const query = `SELECT id, user_id, email, role, expires_at FROM sessions WHERE id = $1`
+
+	var sess session.Session
+	err := s.db.QueryRowContext(ctx, query, id).
+		Scan(&sess.ID, &sess.UserID, &sess.Email, &sess.Role, &sess.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session.Session{}, session.ErrNotFound
+	}
+	if err != nil {
+		return session.Session{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if sess.Expired() {
+		return session.Session{}, session.ErrExpired
+	}
+
+	return sess, nil
+}
+
+func (s *Store) Delete(ctx context.Context, id string) error {
+	const op = "session.postgres.Delete"
+
+	const query = `DELETE FROM sessions WHERE id = $1`
+
+	if _, err := s.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	return nil
+}
+
+// Close closes the underlying connection pool. Callers register it as a
+// lifecycle.Component so it closes on shutdown alongside the main storage
+// pool and SSO client.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,37 @@
+// Package session defines the server-side session abstraction backing the
+// OIDC login flow: a Session is created on callback and looked up by the
+// session middleware on every /user request.
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotFound = errors.New("session: not found")
+	ErrExpired  = errors.New("session: expired")
+)
+
+// Session is a logged-in admin's server-side session record.
+type Session struct {
+	ID        string
+	UserID    int64
+	Email     string
+	Role      string
+	ExpiresAt time.Time
+}
+
+// Expired reports whether the session has passed its ExpiresAt.
+func (s Session) Expired() bool {
+	return time.Now().After(s.ExpiresAt)
+}
+
+// Store persists sessions created by the OIDC callback handler and is
+// consulted by the session middleware on every request.
+type Store interface {
+	Create(ctx context.Context, sess Session) error
+	Get(ctx context.Context, id string) (Session, error)
+	Delete(ctx context.Context, id string) error
+}
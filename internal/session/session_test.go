@@ -0,0 +1,35 @@
+package session_test
+
+import (
+	"testing"
+	"time"
+	"url_shortener/internal/session"
+)
+
+func TestSession_Expired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{
+			name:      "future expiry is not expired",
+			expiresAt: time.Now().Add(time.Hour),
+			want:      false,
+		},
+		{
+			name:      "past expiry is expired",
+			expiresAt: time.Now().Add(-time.Hour),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := session.Session{ExpiresAt: tt.expiresAt}
+			if got := sess.Expired(); got != tt.want {
+				t.Fatalf("Expired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
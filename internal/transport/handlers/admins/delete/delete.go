@@ -0,0 +1,106 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	ssogrpc "url_shortener/internal/clients/sso/grpc"
+	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/lib/authz"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/transport/middleware/auth"
+
+	"github.com/go-chi/render"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequiredScopes is admin:revoke, kept separate from admin:grant so a
+// token that can promote accounts isn't implicitly trusted to demote them.
+// RunServer registers it on authz.Registry, which enforces it.
+var RequiredScopes = []authz.Scope{authz.ScopeAdminRevoke}
+
+type Request struct {
+	Email string `json:"email" validate:"required"`
+	AppID int    `json:"app_id" validate:"requied"`
+}
+
+type Response struct {
+	resp.Response
+}
+
+type PermissionRevoker interface {
+	DeleteAdmin(ctx context.Context, email string, appid int) (bool, error)
+}
+
+func New(permProvider PermissionRevoker, secret string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admins.delete.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		// decode json request
+		var req Request
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, resp.Error("failed to decode request"))
+			return
+		}
+		log.Info("request body decoded", slog.Any("request", req))
+
+		// The caller is already authenticated (the browser-session
+		// middleware gates /user before this handler runs) - mint a
+		// short-lived service token from those claims so SSO, which only
+		// trusts the shared secret, can check the admin:revoke scope.
+		claims, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Error("no authenticated claims on request context")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("unauthorized"))
+			return
+		}
+		serviceToken, err := auth.Sign(secret, claims)
+		if err != nil {
+			log.Error("failed to sign service token", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
+			return
+		}
+		ctx := metadata.NewOutgoingContext(r.Context(), metadata.Pairs("Authorization", "Bearer "+serviceToken))
+
+		_, err = permProvider.DeleteAdmin(ctx, req.Email, req.AppID)
+		if err != nil {
+			log.Error("failed to delete admin", sl.Err(err))
+			switch {
+			case errors.Is(err, ssogrpc.ErrInvalidCredentials):
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("invalid credentials"))
+			case errors.Is(err, ssogrpc.ErrUnauthenticated):
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+			case errors.Is(err, ssogrpc.ErrPermissionDenied):
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, resp.Error("permission denied"))
+			case errors.Is(err, ssogrpc.ErrUserNotFound):
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error("user not found"))
+			case errors.Is(err, ssogrpc.ErrUnavailable):
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, resp.Error("sso unavailable"))
+			case errors.Is(err, ssogrpc.ErrDeadlineExceeded):
+				render.Status(r, http.StatusGatewayTimeout)
+				render.JSON(w, r, resp.Error("sso timeout"))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("error"))
+			}
+			return
+		}
+		log.Info("user removed from admins")
+
+		// response OK
+		render.JSON(w, r, Response{Response: resp.OK()})
+	}
+}
@@ -5,15 +5,22 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
-	"strings"
+	ssogrpc "url_shortener/internal/clients/sso/grpc"
 	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/lib/authz"
+	applogger "url_shortener/internal/lib/logger"
 	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/transport/middleware/auth"
 
-	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"google.golang.org/grpc/metadata"
 )
 
+// RequiredScopes is admin:grant: only a caller already trusted to manage
+// admins may hand the role to someone else. RunServer registers it on
+// authz.Registry, which enforces it.
+var RequiredScopes = []authz.Scope{authz.ScopeAdminGrant}
+
 type Request struct {
 	Email string `json:"email" validate:"required"`
 	AppID int    `json:"app_id" validate:"requied"`
@@ -27,37 +34,11 @@ type PermissionSetter interface {
 	SetAdmin(ctx context.Context, email string, appid int) (bool, error)
 }
 
-func exractToken(header http.Header) (string, error) {
-	if len(header) == 0 {
-		return "", errors.New("no headers in request")
-	}
-	authHeaders, ok := header["Authorization"]
-	if !ok {
-		return "", errors.New("no Authorization in header")
-	}
-	if len(authHeaders) != 1 {
-		return "", errors.New("more than 1 header in request")
-	}
-	auth := authHeaders[0]
-	const prefix = "Bearer "
-	if !strings.HasPrefix(auth, prefix) {
-		return "", errors.New(`missing "Bearer " prefix in "Authorization" header`)
-	}
-	if auth[len(prefix):] == "" {
-		return "", errors.New(`missing token in "Authorization" header`)
-	}
-	return auth, nil
-}
-
-func New(log *slog.Logger, permProvider PermissionSetter) http.HandlerFunc {
+func New(permProvider PermissionSetter, secret string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.admins.set.New"
 
-		// add to log op and reqID
-		log := log.With(
-			slog.String("op", op),
-			slog.String("request_id", middleware.GetReqID(r.Context())),
-		)
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
 
 		// decode json request
 		var req Request
@@ -69,24 +50,52 @@ func New(log *slog.Logger, permProvider PermissionSetter) http.HandlerFunc {
 		}
 		log.Info("request body decoded", slog.Any("request", req))
 
-		token, err := exractToken(r.Header)
+		// The caller is already authenticated (the browser-session
+		// middleware gates /user before this handler runs) - mint a
+		// short-lived service token from those claims so SSO, which only
+		// trusts the shared secret, can check the admin:grant scope.
+		claims, ok := auth.FromContext(r.Context())
+		if !ok {
+			log.Error("no authenticated claims on request context")
+			render.Status(r, http.StatusUnauthorized)
+			render.JSON(w, r, resp.Error("unauthorized"))
+			return
+		}
+		serviceToken, err := auth.Sign(secret, claims)
 		if err != nil {
-			log.Error("failed get JWT token", sl.Err(err))
-			render.JSON(w, r, resp.Error(err.Error()))
+			log.Error("failed to sign service token", sl.Err(err))
+			render.Status(r, http.StatusInternalServerError)
+			render.JSON(w, r, resp.Error("internal error"))
 			return
 		}
-		ctx := metadata.NewOutgoingContext(r.Context(), metadata.Pairs("Authorization", token))
+		ctx := metadata.NewOutgoingContext(r.Context(), metadata.Pairs("Authorization", "Bearer "+serviceToken))
 
 		_, err = permProvider.SetAdmin(ctx, req.Email, req.AppID)
 		if err != nil {
-			errExpect := "grpc.SetAdmin: rpc error: code = InvalidArgument desc = invalid credentials"
-			if err.Error() == errExpect {
-				log.Error("Invalid credential", sl.Err(err))
-				render.JSON(w, r, resp.Error("Invalid credential"))
-				return
+			log.Error("failed to set admin", sl.Err(err))
+			switch {
+			case errors.Is(err, ssogrpc.ErrInvalidCredentials):
+				render.Status(r, http.StatusBadRequest)
+				render.JSON(w, r, resp.Error("invalid credentials"))
+			case errors.Is(err, ssogrpc.ErrUnauthenticated):
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+			case errors.Is(err, ssogrpc.ErrPermissionDenied):
+				render.Status(r, http.StatusForbidden)
+				render.JSON(w, r, resp.Error("permission denied"))
+			case errors.Is(err, ssogrpc.ErrUserNotFound):
+				render.Status(r, http.StatusNotFound)
+				render.JSON(w, r, resp.Error("user not found"))
+			case errors.Is(err, ssogrpc.ErrUnavailable):
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, resp.Error("sso unavailable"))
+			case errors.Is(err, ssogrpc.ErrDeadlineExceeded):
+				render.Status(r, http.StatusGatewayTimeout)
+				render.JSON(w, r, resp.Error("sso timeout"))
+			default:
+				render.Status(r, http.StatusInternalServerError)
+				render.JSON(w, r, resp.Error("error"))
 			}
-			log.Error("error to set admin", sl.Err(err))
-			render.JSON(w, r, resp.Error("error"))
 			return
 		}
 		log.Info("user set to admin")
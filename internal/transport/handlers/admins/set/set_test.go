@@ -0,0 +1,81 @@
+package save_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ssogrpc "url_shortener/internal/clients/sso/grpc"
+	save "url_shortener/internal/transport/handlers/admins/set"
+	"url_shortener/internal/transport/middleware/auth"
+)
+
+const testSecret = "test-secret"
+
+type stubSetter struct {
+	err error
+}
+
+func (s stubSetter) SetAdmin(context.Context, string, int) (bool, error) {
+	return s.err == nil, s.err
+}
+
+func newRequest(t *testing.T, withClaims bool) *http.Request {
+	t.Helper()
+
+	body, err := json.Marshal(save.Request{Email: "a@b.com", AppID: 1})
+	if err != nil {
+		t.Fatalf("failed to marshal request body: %v", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/user", bytes.NewReader(body))
+	if !withClaims {
+		return r
+	}
+	return r.WithContext(auth.NewContext(r.Context(), auth.Claims{UID: 1, Scopes: []string{"admin:grant"}}))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		srvErr     error
+		wantStatus int
+	}{
+		{name: "success", wantStatus: http.StatusOK},
+		{name: "invalid credentials maps to 400", srvErr: ssogrpc.ErrInvalidCredentials, wantStatus: http.StatusBadRequest},
+		{name: "unauthenticated maps to 401", srvErr: ssogrpc.ErrUnauthenticated, wantStatus: http.StatusUnauthorized},
+		{name: "permission denied maps to 403", srvErr: ssogrpc.ErrPermissionDenied, wantStatus: http.StatusForbidden},
+		{name: "user not found maps to 404", srvErr: ssogrpc.ErrUserNotFound, wantStatus: http.StatusNotFound},
+		{name: "unavailable maps to 503", srvErr: ssogrpc.ErrUnavailable, wantStatus: http.StatusServiceUnavailable},
+		{name: "deadline exceeded maps to 504", srvErr: ssogrpc.ErrDeadlineExceeded, wantStatus: http.StatusGatewayTimeout},
+		{name: "unmapped error falls back to 500", srvErr: errors.New("boom"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := save.New(stubSetter{err: tt.srvErr}, testSecret)
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, newRequest(t, true))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNew_NoAuthenticatedClaimsIsUnauthorized(t *testing.T) {
+	handler := save.New(stubSetter{}, testSecret)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, newRequest(t, false))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,135 @@
+// Package callback completes the OIDC authorization-code + PKCE flow
+// started by handlers/auth/login: it validates state and nonce, exchanges
+// the code for an id_token, and opens a server-side session.
+package callback
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	resp "url_shortener/internal/lib/api/response"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/lib/oauth"
+	"url_shortener/internal/session"
+	"url_shortener/internal/transport/handlers/auth/login"
+
+	"github.com/go-chi/render"
+)
+
+const sessionTTL = 24 * time.Hour
+
+// TokenExchanger trades an authorization code plus its PKCE verifier for a
+// verified identity: subject, email, role and the nonce that was embedded
+// in the id_token, so Callback can detect a replayed authorization code.
+type TokenExchanger interface {
+	Exchange(ctx context.Context, code, codeVerifier string) (sub, email, role, nonce string, err error)
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func New(secret string, exchanger TokenExchanger, sessions session.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.callback.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		flowCookie, err := r.Cookie(login.FlowCookie)
+		if err != nil {
+			log.Info("missing oidc flow cookie", sl.Err(err))
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		http.SetCookie(w, &http.Cookie{Name: login.FlowCookie, Value: "", Path: "/user/auth", MaxAge: -1})
+
+		flow, err := oauth.VerifyFlow(secret, flowCookie.Value)
+		if err != nil {
+			log.Info("bad flow cookie", sl.Err(err))
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		parts := strings.SplitN(flow, "|", 3)
+		if len(parts) != 3 {
+			log.Info("malformed flow cookie")
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		wantState, wantNonce, verifier := parts[0], parts[1], parts[2]
+
+		if state := r.URL.Query().Get("state"); state != wantState {
+			log.Info("state mismatch", slog.String("got", state))
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			log.Info("missing code")
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		sub, email, role, nonce, err := exchanger.Exchange(r.Context(), code, verifier)
+		if err != nil {
+			log.Error("failed to exchange code", sl.Err(err))
+			http.Error(w, "failed to complete login", http.StatusUnauthorized)
+			return
+		}
+		if nonce != wantNonce {
+			log.Info("nonce mismatch, possible replay")
+			http.Error(w, "nonce mismatch", http.StatusUnauthorized)
+			return
+		}
+
+		uid, err := strconv.ParseInt(sub, 10, 64)
+		if err != nil {
+			log.Error("non-numeric subject claim", sl.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		sessionID, err := newSessionID()
+		if err != nil {
+			log.Error("failed to generate session id", sl.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		sess := session.Session{
+			ID:        sessionID,
+			UserID:    uid,
+			Email:     email,
+			Role:      role,
+			ExpiresAt: time.Now().Add(sessionTTL),
+		}
+		if err := sessions.Create(r.Context(), sess); err != nil {
+			log.Error("failed to create session", sl.Err(err))
+			render.JSON(w, r, resp.Error("internal error"))
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     "session_id",
+			Value:    sessionID,
+			Path:     "/",
+			Expires:  sess.ExpiresAt,
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		log.Info("session created", slog.Int64("uid", uid))
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
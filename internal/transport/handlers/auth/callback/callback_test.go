@@ -0,0 +1,112 @@
+package callback_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"url_shortener/internal/lib/oauth"
+	sessionmem "url_shortener/internal/session/memory"
+	"url_shortener/internal/transport/handlers/auth/callback"
+	"url_shortener/internal/transport/handlers/auth/login"
+)
+
+const testSecret = "test-secret"
+
+type fakeExchanger struct {
+	sub, email, role, nonce string
+	err                     error
+}
+
+func (f fakeExchanger) Exchange(_ context.Context, _, _ string) (string, string, string, string, error) {
+	return f.sub, f.email, f.role, f.nonce, f.err
+}
+
+func newCallbackRequest(t *testing.T, query, flowState, flowNonce, flowVerifier string) *http.Request {
+	t.Helper()
+
+	r := httptest.NewRequest(http.MethodGet, "/user/auth/callback?"+query, nil)
+	flow := flowState + "|" + flowNonce + "|" + flowVerifier
+	r.AddCookie(&http.Cookie{Name: login.FlowCookie, Value: oauth.SignFlow(testSecret, flow)})
+	return r
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		flowState  string
+		flowNonce  string
+		exchanger  fakeExchanger
+		noCookie   bool
+		wantStatus int
+	}{
+		{
+			name:       "matching state and nonce creates a session",
+			query:      "state=want-state&code=auth-code",
+			flowState:  "want-state",
+			flowNonce:  "want-nonce",
+			exchanger:  fakeExchanger{sub: "42", email: "a@b.com", role: "admin", nonce: "want-nonce"},
+			wantStatus: http.StatusFound,
+		},
+		{
+			name:       "state mismatch is rejected as CSRF",
+			query:      "state=attacker-state&code=auth-code",
+			flowState:  "want-state",
+			flowNonce:  "want-nonce",
+			exchanger:  fakeExchanger{sub: "42", nonce: "want-nonce"},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "nonce mismatch is rejected as a replayed id_token",
+			query:      "state=want-state&code=auth-code",
+			flowState:  "want-state",
+			flowNonce:  "want-nonce",
+			exchanger:  fakeExchanger{sub: "42", nonce: "replayed-nonce"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing flow cookie is a bad request",
+			query:      "state=want-state&code=auth-code",
+			noCookie:   true,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := sessionmem.New()
+			handler := callback.New(testSecret, tt.exchanger, store)
+
+			var r *http.Request
+			if tt.noCookie {
+				r = httptest.NewRequest(http.MethodGet, "/user/auth/callback?"+tt.query, nil)
+			} else {
+				r = newCallbackRequest(t, tt.query, tt.flowState, tt.flowNonce, "verifier")
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNew_TamperedFlowCookieIsRejected(t *testing.T) {
+	store := sessionmem.New()
+	handler := callback.New(testSecret, fakeExchanger{sub: "42", nonce: "want-nonce"}, store)
+
+	r := httptest.NewRequest(http.MethodGet, "/user/auth/callback?state=want-state&code=auth-code", nil)
+	flow := "want-state|want-nonce|verifier"
+	r.AddCookie(&http.Cookie{Name: login.FlowCookie, Value: oauth.SignFlow("wrong-secret", flow)})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d (tampered flow cookie signature must be rejected)", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,64 @@
+// Package login starts the OIDC authorization-code + PKCE flow for the
+// /user admin area.
+package login
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/lib/oauth"
+)
+
+// FlowCookie carries the signed state|nonce|code_verifier triple across
+// the redirect to the SSO authorize endpoint and back to Callback.
+const FlowCookie = "oidc_flow"
+
+const flowCookieTTL = 10 * time.Minute
+
+// AuthURLBuilder builds the SSO authorization endpoint URL for a PKCE flow.
+type AuthURLBuilder interface {
+	AuthURL(state, nonce, codeChallenge string) string
+}
+
+func New(secret string, builder AuthURLBuilder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.login.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		state, err := oauth.GenerateState()
+		if err != nil {
+			log.Error("failed to generate state", sl.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		nonce, err := oauth.GenerateNonce()
+		if err != nil {
+			log.Error("failed to generate nonce", sl.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+		verifier, err := oauth.GenerateVerifier()
+		if err != nil {
+			log.Error("failed to generate code verifier", sl.Err(err))
+			http.Error(w, "internal error", http.StatusInternalServerError)
+			return
+		}
+
+		flow := state + "|" + nonce + "|" + verifier
+		http.SetCookie(w, &http.Cookie{
+			Name:     FlowCookie,
+			Value:    oauth.SignFlow(secret, flow),
+			Path:     "/user/auth",
+			Expires:  time.Now().Add(flowCookieTTL),
+			HttpOnly: true,
+			Secure:   true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		log.Info("redirecting to sso authorize endpoint")
+		http.Redirect(w, r, builder.AuthURL(state, nonce, oauth.Challenge(verifier)), http.StatusFound)
+	}
+}
@@ -0,0 +1,34 @@
+// Package logout ends the caller's browser session.
+package logout
+
+import (
+	"log/slog"
+	"net/http"
+	resp "url_shortener/internal/lib/api/response"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/session"
+
+	"github.com/go-chi/render"
+)
+
+const cookieName = "session_id"
+
+func New(sessions session.Store) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.auth.logout.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		if cookie, err := r.Cookie(cookieName); err == nil {
+			if err := sessions.Delete(r.Context(), cookie.Value); err != nil {
+				log.Error("failed to delete session", sl.Err(err))
+			}
+		}
+
+		http.SetCookie(w, &http.Cookie{Name: cookieName, Value: "", Path: "/", MaxAge: -1})
+
+		log.Info("session ended")
+		render.JSON(w, r, resp.OK())
+	}
+}
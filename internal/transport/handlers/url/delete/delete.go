@@ -0,0 +1,58 @@
+package delete
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/lib/authz"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	"url_shortener/internal/storage"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// RequiredScopes is url:delete, distinct from url:write so a token that can
+// create links doesn't automatically get to remove them. RunServer
+// registers it on authz.Registry, which enforces it.
+var RequiredScopes = []authz.Scope{authz.ScopeURLDelete}
+
+type Response struct {
+	resp.Response
+}
+
+type URLDeleter interface {
+	DeleteURL(alias string) error
+}
+
+func New(urlDeleter URLDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.delete.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		alias := chi.URLParam(r, "alias")
+		if alias == "" {
+			log.Info("alias is empty")
+			render.JSON(w, r, resp.Error("invalid request"))
+			return
+		}
+
+		err := urlDeleter.DeleteURL(alias)
+		if err != nil {
+			if errors.Is(err, storage.ErrURLNotFound) {
+				log.Info("wrong alias", slog.String("alias", alias))
+				render.JSON(w, r, resp.Error("wrong alias"))
+				return
+			}
+			log.Error("failed to delete url", sl.Err(err))
+			render.JSON(w, r, resp.Error("internal error"))
+			return
+		}
+		log.Info("url deleted", slog.String("alias", alias))
+
+		render.JSON(w, r, Response{Response: resp.OK()})
+	}
+}
@@ -5,27 +5,28 @@ import (
 	"log/slog"
 	"net/http"
 	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/lib/authz"
+	applogger "url_shortener/internal/lib/logger"
 	"url_shortener/internal/lib/logger/sl"
 	"url_shortener/internal/storage"
 
-	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 )
 
+// RequiredScopes is empty: redirecting a short link is a public,
+// unauthenticated route.
+var RequiredScopes []authz.Scope
+
 type URLGetter interface {
 	GetURL(alias string) (string, error)
 }
 
-func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
+func New(urlGetter URLGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.url.redirect.New"
 
-		// add to log op and reqID
-		log := log.With(
-			slog.String("op", op),
-			slog.String("request_id", middleware.GetReqID(r.Context())),
-		)
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
 
 		alias := chi.URLParam(r, "alias")
 		if alias == "" {
@@ -42,7 +43,7 @@ func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
 				render.JSON(w, r, resp.Error("wrong alias"))
 				return
 			}
-			log.Info("failed to get url", sl.Err(err))
+			log.Error("failed to get url", sl.Err(err))
 			render.JSON(w, r, resp.Error("internal error"))
 			return
 		}
@@ -50,4 +51,4 @@ func New(log *slog.Logger, urlGetter URLGetter) http.HandlerFunc {
 
 		http.Redirect(w, r, resURL, http.StatusFound)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,60 @@
+package save
+
+import (
+	"log/slog"
+	"net/http"
+	resp "url_shortener/internal/lib/api/response"
+	"url_shortener/internal/lib/authz"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+
+	"github.com/go-chi/render"
+)
+
+// RequiredScopes is url:write: minting a short link is a write on the
+// caller's own URLs, so a read-only token must not reach this handler.
+// RunServer registers it on authz.Registry, which enforces it.
+var RequiredScopes = []authz.Scope{authz.ScopeURLWrite}
+
+type Request struct {
+	URL   string `json:"url" validate:"required,url"`
+	Alias string `json:"alias,omitempty"`
+}
+
+type Response struct {
+	resp.Response
+	Alias string `json:"alias,omitempty"`
+}
+
+type URLSaver interface {
+	SaveURL(urlToSave string, alias string) (int64, error)
+}
+
+func New(urlSaver URLSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.url.save.New"
+
+		log := applogger.FromContext(r.Context()).With(slog.String("op", op))
+
+		// decode json request
+		var req Request
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request body", sl.Err(err))
+			render.JSON(w, r, resp.Error("failed to decode request"))
+			return
+		}
+		log.Info("request body decoded", slog.Any("request", req))
+
+		alias := req.Alias
+		id, err := urlSaver.SaveURL(req.URL, alias)
+		if err != nil {
+			log.Error("failed to save url", sl.Err(err))
+			render.JSON(w, r, resp.Error("failed to save url"))
+			return
+		}
+		log.Info("url saved", slog.Int64("id", id))
+
+		render.JSON(w, r, Response{Response: resp.OK(), Alias: alias})
+	}
+}
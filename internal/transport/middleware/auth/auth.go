@@ -0,0 +1,138 @@
+// Package auth authenticates the bearer JWT on a request and stashes the
+// resulting claims on the request context for downstream middleware
+// (see internal/lib/authz) and handlers to read.
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	resp "url_shortener/internal/lib/api/response"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+
+	"github.com/go-chi/render"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// serviceTokenTTL bounds how long a token minted by Sign is valid for. It's
+// short because Sign only exists to let one already-authenticated request
+// make a single downstream SSO RPC - nothing should ever store or replay it.
+const serviceTokenTTL = time.Minute
+
+type ctxKey int
+
+const claimsCtxKey ctxKey = iota
+
+// Claims are the JWT claims issued by the SSO service.
+type Claims struct {
+	jwt.RegisteredClaims
+	UID    int64    `json:"uid"`
+	Scopes []string `json:"scopes"`
+}
+
+// RevocationChecker reports whether a token has been revoked by the SSO
+// service ahead of its natural expiry.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+// FromContext returns the claims New stored on the request context.
+func FromContext(ctx context.Context) (Claims, bool) {
+	claims, ok := ctx.Value(claimsCtxKey).(Claims)
+	return claims, ok
+}
+
+// NewContext stores claims on ctx the same way New does, so other
+// authentication middleware (e.g. the browser-session middleware backing
+// /user) can feed authz.RequireScopes without duplicating its logic.
+func NewContext(ctx context.Context, claims Claims) context.Context {
+	return context.WithValue(ctx, claimsCtxKey, claims)
+}
+
+// Sign mints a short-lived JWT from claims, signed with secret. The admin
+// handlers use it to turn the caller's already-validated claims - whether
+// they came from a bearer token or a browser session - into a service
+// token for the one downstream SSO RPC the request needs, since SSO only
+// trusts the shared secret, not this service's session cookies.
+func Sign(secret string, claims Claims) (string, error) {
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(serviceTokenTTL))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+func extractToken(header http.Header) (string, error) {
+	authHeader := header.Get("Authorization")
+	if authHeader == "" {
+		return "", errors.New("no Authorization in header")
+	}
+	const prefix = "Bearer "
+	if !strings.HasPrefix(authHeader, prefix) {
+		return "", errors.New(`missing "Bearer " prefix in "Authorization" header`)
+	}
+	token := strings.TrimPrefix(authHeader, prefix)
+	if token == "" {
+		return "", errors.New(`missing token in "Authorization" header`)
+	}
+	return token, nil
+}
+
+// New returns a chi middleware that verifies the bearer JWT against secret,
+// rejects it if revocation reports it revoked, and stores the claims on the
+// request context. It replaces the previous all-or-nothing
+// is-authenticated gate: callers now also get the token's scopes, which
+// internal/lib/authz.RequireScopes checks per route.
+func New(secret string, revocation RevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.auth.New"
+
+			baseLog := applogger.FromContext(r.Context())
+			log := baseLog.With(slog.String("op", op))
+
+			tokenString, err := extractToken(r.Header)
+			if err != nil {
+				log.Info("failed to extract token", sl.Err(err))
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+				return
+			}
+
+			var claims Claims
+			_, err = jwt.ParseWithClaims(tokenString, &claims, func(*jwt.Token) (interface{}, error) {
+				return []byte(secret), nil
+			}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name}))
+			if err != nil {
+				log.Info("invalid token", sl.Err(err))
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+				return
+			}
+
+			if claims.ID != "" && revocation != nil {
+				revoked, err := revocation.IsRevoked(r.Context(), claims.ID)
+				if err != nil {
+					log.Error("failed to check token revocation", sl.Err(err))
+					render.Status(r, http.StatusServiceUnavailable)
+					render.JSON(w, r, resp.Error("internal error"))
+					return
+				}
+				if revoked {
+					log.Info("token revoked", slog.String("jti", claims.ID))
+					render.Status(r, http.StatusUnauthorized)
+					render.JSON(w, r, resp.Error("unauthorized"))
+					return
+				}
+			}
+
+			log.Info("token verified", slog.Int64("uid", claims.UID))
+			ctx := NewContext(r.Context(), claims)
+			ctx = applogger.NewContext(ctx, baseLog.With(slog.Int64("user_id", claims.UID)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
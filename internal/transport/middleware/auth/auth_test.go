@@ -0,0 +1,149 @@
+package auth_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/transport/middleware/auth"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testSecret = "test-secret"
+
+func signToken(t *testing.T, method jwt.SigningMethod, claims auth.Claims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	signed, err := token.SignedString([]byte(testSecret))
+	if err != nil {
+		t.Fatalf("failed to sign test token: %v", err)
+	}
+	return signed
+}
+
+func TestNew(t *testing.T) {
+	validClaims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		UID:    42,
+		Scopes: []string{"url:write"},
+	}
+	expiredClaims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Hour)),
+		},
+		UID: 42,
+	}
+
+	tests := []struct {
+		name       string
+		authHeader string
+		wantStatus int
+	}{
+		{
+			name:       "valid token with scopes is accepted",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, validClaims),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "expired token is rejected",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS256, expiredClaims),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "missing Authorization header is rejected",
+			authHeader: "",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "token signed with HS384 instead of HS256 is rejected",
+			authHeader: "Bearer " + signToken(t, jwt.SigningMethodHS384, validClaims),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reachedHandler bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reachedHandler = true
+				if _, ok := auth.FromContext(r.Context()); !ok {
+					t.Error("handler did not see claims on the request context")
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			handler := auth.New(testSecret, nil)(next)
+
+			r := httptest.NewRequest(http.MethodPost, "/url", nil)
+			if tt.authHeader != "" {
+				r.Header.Set("Authorization", tt.authHeader)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && !reachedHandler {
+				t.Error("handler was never called despite a 200 response")
+			}
+			if tt.wantStatus != http.StatusOK && reachedHandler {
+				t.Error("handler was called despite a rejected token")
+			}
+		})
+	}
+}
+
+// captureHandler is a minimal slog.Handler recording the attrs attached via
+// With, so the test can assert on the logger New stashes back on the
+// request context without depending on output formatting.
+type captureHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool  { return true }
+func (h *captureHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &captureHandler{attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func TestNew_EnrichesContextLoggerWithUserID(t *testing.T) {
+	validClaims := auth.Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		UID: 42,
+	}
+
+	var gotHandler *captureHandler
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHandler, _ = applogger.FromContext(r.Context()).Handler().(*captureHandler)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/url", nil)
+	r.Header.Set("Authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, validClaims))
+	r = r.WithContext(applogger.NewContext(r.Context(), slog.New(&captureHandler{})))
+
+	w := httptest.NewRecorder()
+	auth.New(testSecret, nil)(next).ServeHTTP(w, r)
+
+	if gotHandler == nil {
+		t.Fatal("handler did not see a context logger")
+	}
+	for _, a := range gotHandler.attrs {
+		if a.Key == "user_id" && a.Value.Int64() == 42 {
+			return
+		}
+	}
+	t.Error("context logger was not enriched with user_id after authentication")
+}
@@ -0,0 +1,41 @@
+// Package logger is the middleware, mounted after chi's RequestID, that
+// builds a per-request child of the root logger carrying request_id,
+// remote_ip, method and path, and stores it on the request context via
+// internal/lib/logger for every downstream middleware and handler to read.
+// middleware/auth and middleware/session add user_id to that context
+// logger once they have authenticated the caller.
+package logger
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+	applogger "url_shortener/internal/lib/logger"
+
+	"github.com/go-chi/chi/middleware"
+)
+
+func New(log *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			reqLog := log.With(
+				slog.String("request_id", middleware.GetReqID(r.Context())),
+				slog.String("remote_ip", r.RemoteAddr),
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+			)
+			ctx := applogger.NewContext(r.Context(), reqLog)
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			reqLog.Info("request completed",
+				slog.Int("status", ww.Status()),
+				slog.Int("bytes", ww.BytesWritten()),
+				slog.Duration("duration", time.Since(start)),
+			)
+		})
+	}
+}
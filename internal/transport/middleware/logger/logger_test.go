@@ -0,0 +1,79 @@
+package logger_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	applogger "url_shortener/internal/lib/logger"
+	mwLogger "url_shortener/internal/transport/middleware/logger"
+)
+
+// captureHandler is a minimal slog.Handler that records the attrs attached
+// via With, so tests can assert on the logger stashed on the request
+// context without depending on any particular output format.
+type captureHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *captureHandler) Enabled(context.Context, slog.Level) bool { return true }
+func (h *captureHandler) Handle(context.Context, slog.Record) error {
+	return nil
+}
+func (h *captureHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &captureHandler{attrs: append(append([]slog.Attr{}, h.attrs...), attrs...)}
+}
+func (h *captureHandler) WithGroup(string) slog.Handler { return h }
+
+func attrValue(attrs []slog.Attr, key string) (string, bool) {
+	for _, a := range attrs {
+		if a.Key == key {
+			return a.Value.String(), true
+		}
+	}
+	return "", false
+}
+
+func TestNew_AttachesRequestScopedAttributes(t *testing.T) {
+	handler := &captureHandler{}
+	root := slog.New(handler)
+
+	var gotHandler *captureHandler
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHandler = applogger.FromContext(r.Context()).Handler().(*captureHandler)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+	r.RemoteAddr = "203.0.113.1:54321"
+	w := httptest.NewRecorder()
+
+	mwLogger.New(root)(next).ServeHTTP(w, r)
+
+	if gotHandler == nil {
+		t.Fatal("handler never received a context logger")
+	}
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"remote_ip", "203.0.113.1:54321"},
+		{"method", http.MethodGet},
+		{"path", "/abc123"},
+	}
+	for _, tt := range tests {
+		got, ok := attrValue(gotHandler.attrs, tt.key)
+		if !ok {
+			t.Errorf("attribute %q was not attached", tt.key)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("attribute %q = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	if _, ok := attrValue(gotHandler.attrs, "request_id"); !ok {
+		t.Error("attribute \"request_id\" was not attached")
+	}
+}
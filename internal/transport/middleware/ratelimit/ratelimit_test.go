@@ -0,0 +1,81 @@
+package ratelimit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"url_shortener/internal/transport/middleware/ratelimit"
+)
+
+type stubLimiter struct {
+	allowed    bool
+	remaining  int
+	retryAfter time.Duration
+}
+
+func (s stubLimiter) Allow(string) (bool, int, time.Duration) {
+	return s.allowed, s.remaining, s.retryAfter
+}
+
+func TestNew_AllowedRequestSetsRemainingHeaderAndProceeds(t *testing.T) {
+	var reached bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	})
+
+	handler := ratelimit.New(stubLimiter{allowed: true, remaining: 4}, ratelimit.ByIP)(next)
+
+	r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if !reached {
+		t.Fatal("next handler was not called for an allowed request")
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "4" {
+		t.Errorf("X-RateLimit-Remaining = %q, want %q", got, "4")
+	}
+}
+
+func TestNew_DeniedRequestRespondsTooManyRequests(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("next handler was called despite a denied request")
+	})
+
+	handler := ratelimit.New(stubLimiter{allowed: false, retryAfter: 2 * time.Second}, ratelimit.ByIP)(next)
+
+	r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "3" {
+		t.Errorf("Retry-After = %q, want %q", got, "3")
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("X-RateLimit-Remaining = %q, want %q", got, "0")
+	}
+}
+
+func TestByIP_StripsPort(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	if got := ratelimit.ByIP(r); got != "192.0.2.1" {
+		t.Errorf("ByIP() = %q, want %q", got, "192.0.2.1")
+	}
+}
+
+func TestBySubjectOrIP_FallsBackToIPWithoutClaims(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/abc", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	if got := ratelimit.BySubjectOrIP(r); got != "192.0.2.1" {
+		t.Errorf("BySubjectOrIP() = %q, want %q", got, "192.0.2.1")
+	}
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// TokenBucket is an in-memory, per-key token bucket Limiter. It refills
+// lazily on Allow rather than on a timer, so idle keys cost nothing between
+// requests.
+type TokenBucket struct {
+	mu      sync.Mutex
+	cfg     Config
+	buckets map[string]*bucket
+	now     func() time.Time // overridden in tests; defaults to time.Now
+}
+
+// NewTokenBucket returns a TokenBucket enforcing cfg's RPS and burst.
+func NewTokenBucket(cfg Config) *TokenBucket {
+	return &TokenBucket{cfg: cfg, buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow implements Limiter.
+func (tb *TokenBucket) Allow(key string) (bool, int, time.Duration) {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	now := tb.now()
+	b, ok := tb.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(tb.cfg.Burst), lastRefill: now}
+		tb.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.lastRefill).Seconds()
+	b.tokens += elapsed * tb.cfg.RPS
+	if max := float64(tb.cfg.Burst); b.tokens > max {
+		b.tokens = max
+	}
+	b.lastRefill = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / tb.cfg.RPS * float64(time.Second))
+		return false, 0, wait
+	}
+
+	b.tokens--
+	return true, int(b.tokens), 0
+}
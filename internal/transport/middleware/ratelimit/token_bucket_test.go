@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket_Allow_ExhaustsBurstThenBlocks(t *testing.T) {
+	tb := NewTokenBucket(Config{RPS: 1, Burst: 3})
+	now := time.Unix(0, 0)
+	tb.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		allowed, _, _ := tb.Allow("k")
+		if !allowed {
+			t.Fatalf("request %d: Allow() = false, want true within burst", i)
+		}
+	}
+
+	allowed, remaining, retryAfter := tb.Allow("k")
+	if allowed {
+		t.Fatal("Allow() = true after burst exhausted, want false")
+	}
+	if remaining != 0 {
+		t.Errorf("remaining = %d, want 0", remaining)
+	}
+	if retryAfter <= 0 {
+		t.Errorf("retryAfter = %v, want > 0", retryAfter)
+	}
+}
+
+func TestTokenBucket_Allow_RefillsAsClockAdvances(t *testing.T) {
+	tb := NewTokenBucket(Config{RPS: 1, Burst: 1})
+	now := time.Unix(0, 0)
+	tb.now = func() time.Time { return now }
+
+	if allowed, _, _ := tb.Allow("k"); !allowed {
+		t.Fatal("first Allow() = false, want true")
+	}
+	if allowed, _, _ := tb.Allow("k"); allowed {
+		t.Fatal("second Allow() before refill = true, want false")
+	}
+
+	now = now.Add(time.Second)
+	if allowed, _, _ := tb.Allow("k"); !allowed {
+		t.Fatal("Allow() after a full second elapsed = false, want true")
+	}
+}
+
+func TestTokenBucket_Allow_KeysAreIndependent(t *testing.T) {
+	tb := NewTokenBucket(Config{RPS: 1, Burst: 1})
+	now := time.Unix(0, 0)
+	tb.now = func() time.Time { return now }
+
+	if allowed, _, _ := tb.Allow("a"); !allowed {
+		t.Fatal("Allow(\"a\") = false, want true")
+	}
+	if allowed, _, _ := tb.Allow("b"); !allowed {
+		t.Fatal("Allow(\"b\") = false, want true (distinct key, own bucket)")
+	}
+}
+
+func TestTokenBucket_Allow_ConcurrentAccessIsSafe(t *testing.T) {
+	tb := NewTokenBucket(Config{RPS: 1000, Burst: 1000})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				tb.Allow("shared-key")
+			}
+		}()
+	}
+	wg.Wait()
+}
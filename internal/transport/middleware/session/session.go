@@ -0,0 +1,69 @@
+// Package session is the chi middleware gating /user: it reads the
+// session cookie set by handlers/auth/callback, looks it up in the
+// session.Store, and feeds the resulting role as scopes into the request
+// context so authz.RequireScopes can gate admSet/admDel exactly as it
+// gates the bearer-JWT-authenticated /url routes.
+package session
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	resp "url_shortener/internal/lib/api/response"
+	applogger "url_shortener/internal/lib/logger"
+	"url_shortener/internal/lib/logger/sl"
+	sess "url_shortener/internal/session"
+	"url_shortener/internal/transport/middleware/auth"
+
+	"github.com/go-chi/render"
+)
+
+const CookieName = "session_id"
+
+// roleScopes maps a session's role to the scopes authz.RequireScopes
+// expects on the claims it reads from the request context.
+func roleScopes(role string) []string {
+	if role == "admin" {
+		return []string{"admin:*"}
+	}
+	return nil
+}
+
+func New(store sess.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			const op = "middleware.session.New"
+
+			baseLog := applogger.FromContext(r.Context())
+			log := baseLog.With(slog.String("op", op))
+
+			cookie, err := r.Cookie(CookieName)
+			if err != nil {
+				log.Info("no session cookie")
+				render.Status(r, http.StatusUnauthorized)
+				render.JSON(w, r, resp.Error("unauthorized"))
+				return
+			}
+
+			s, err := store.Get(r.Context(), cookie.Value)
+			if err != nil {
+				if errors.Is(err, sess.ErrNotFound) || errors.Is(err, sess.ErrExpired) {
+					log.Info("session not found or expired", sl.Err(err))
+					render.Status(r, http.StatusUnauthorized)
+					render.JSON(w, r, resp.Error("unauthorized"))
+					return
+				}
+				log.Error("failed to load session", sl.Err(err))
+				render.Status(r, http.StatusServiceUnavailable)
+				render.JSON(w, r, resp.Error("internal error"))
+				return
+			}
+
+			log.Info("session verified", slog.Int64("uid", s.UserID))
+			claims := auth.Claims{UID: s.UserID, Scopes: roleScopes(s.Role)}
+			ctx := auth.NewContext(r.Context(), claims)
+			ctx = applogger.NewContext(ctx, baseLog.With(slog.Int64("user_id", s.UserID)))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}